@@ -0,0 +1,33 @@
+package main
+
+// Hashmap grow/evacuate stress: build a map[int32]int32, delete half its
+// entries, and sum what's left. Exercises the TinyGo/Go hashmap the way the
+// rest of the suite's raw-pointer benchmarks never do.
+// Param: iteration count. Returns: int32 checksum (low bits of the
+// accumulated sum).
+
+const allocMapSize = 500
+
+//export alloc_map
+func alloc_map(iters int32) int32 {
+	var total int64
+
+	for iter := int32(0); iter < iters; iter++ {
+		m := make(map[int32]int32)
+		for i := int32(0); i < allocMapSize; i++ {
+			m[i] = i * i
+		}
+		for i := int32(0); i < allocMapSize; i += 2 {
+			delete(m, i)
+		}
+
+		var sum int64
+		for _, v := range m {
+			sum += int64(v)
+		}
+		total += sum
+	}
+	return int32(total & 0x7FFFFFFF)
+}
+
+func main() {}
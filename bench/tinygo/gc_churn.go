@@ -0,0 +1,37 @@
+package main
+
+// GC-stress benchmark using real heap allocations, unlike the rest of the
+// suite which writes through unsafe pointers into linear memory. Each
+// iteration builds a linked list of heap-allocated nodes, walks it summing
+// values, then drops the reference so the collector has to reclaim it.
+// Param: iteration count. Returns: int32 checksum (low bits of the
+// accumulated sum).
+
+const gcChurnSize = 500
+
+type gcNode struct {
+	val  int32
+	next *gcNode
+}
+
+//export gc_churn
+func gc_churn(iters int32) int32 {
+	var total int64
+
+	for iter := int32(0); iter < iters; iter++ {
+		var head *gcNode
+		for i := int32(0); i < gcChurnSize; i++ {
+			head = &gcNode{val: i, next: head}
+		}
+
+		var sum int64
+		for n := head; n != nil; n = n.next {
+			sum += int64(n.val)
+		}
+		total += sum
+		head = nil
+	}
+	return int32(total & 0x7FFFFFFF)
+}
+
+func main() {}
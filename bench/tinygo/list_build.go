@@ -1,6 +1,10 @@
 package main
 
-import "unsafe"
+import (
+	"unsafe"
+
+	"github.com/clojurewasm/zwasm/bench/tinygo/scratch"
+)
 
 // Linked list build + traverse using raw linear memory.
 // Fixed list size 500, param controls iteration count.
@@ -9,13 +13,12 @@ import "unsafe"
 // Each node: [val int32 (4 bytes), next_offset int32 (4 bytes)] = 8 bytes.
 // next_offset = 0 means nil (no next node).
 
-const listScratch = 1024
 const nodeSize = 8
 const listSize = 500
 
 //export list_build
 func list_build(iters int32) int32 {
-	base := unsafe.Pointer(uintptr(listScratch))
+	base := scratch.Reserve((listSize + 1) * nodeSize)
 	var total int32
 
 	for iter := int32(0); iter < iters; iter++ {
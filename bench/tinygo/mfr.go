@@ -1,18 +1,21 @@
 package main
 
-import "unsafe"
+import (
+	"unsafe"
+
+	"github.com/clojurewasm/zwasm/bench/tinygo/scratch"
+)
 
 // Map-filter-reduce: allocate array, square each element,
 // filter even values, sum them. Repeated for `iters` iterations.
 // Fixed array size 500, param controls iteration count.
 // Returns: int32 checksum (low bits of accumulated sum).
 
-const mfrScratch = 1024
 const mfrSize = 500
 
 //export mfr
 func mfr(iters int32) int32 {
-	base := unsafe.Pointer(uintptr(mfrScratch))
+	base := scratch.Reserve(mfrSize * 8)
 	var total int64
 
 	for iter := int32(0); iter < iters; iter++ {
@@ -1,6 +1,10 @@
 package main
 
-import "unsafe"
+import (
+	"unsafe"
+
+	"github.com/clojurewasm/zwasm/bench/tinygo/scratch"
+)
 
 // N-Queens solver via iterative backtracking.
 // Param: iteration count (solve N=8 board multiple times).
@@ -9,12 +13,11 @@ import "unsafe"
 // Uses iterative approach with explicit row stack stored in linear memory
 // to avoid recursive function calls and array pointer passing.
 
-const nqScratch = 1024
 const nqBoardSize = 8
 
 //export nqueens
 func nqueens(iters int32) int32 {
-	base := unsafe.Pointer(uintptr(nqScratch))
+	base := scratch.Reserve(nqBoardSize * 4)
 	var total int32
 
 	for iter := int32(0); iter < iters; iter++ {
@@ -61,4 +64,37 @@ func nqueens(iters int32) int32 {
 	return total
 }
 
+const nqFullMask = uint32(1)<<nqBoardSize - 1
+
+// nqueens_rec solves the same N=8 board as nqueens, but via straightforward
+// recursion instead of an explicit stack in linear memory, so the two
+// variants let a host measure the per-call overhead its wasm toolchain
+// imposes. Param: iteration count. Returns: total solutions found across
+// all iterations.
+//
+//export nqueens_rec
+func nqueens_rec(iters int32) int32 {
+	var total int32
+	for iter := int32(0); iter < iters; iter++ {
+		total += solve(0, 0, 0, 0)
+	}
+	return total
+}
+
+// solve counts completions of the partial board described by the occupied
+// column and diagonal bitmasks cols/diag1/diag2, from the given row onward.
+func solve(row int32, cols, diag1, diag2 uint32) int32 {
+	if row == nqBoardSize {
+		return 1
+	}
+	count := int32(0)
+	available := nqFullMask &^ (cols | diag1 | diag2)
+	for available != 0 {
+		bit := available & -available
+		available &^= bit
+		count += solve(row+1, cols|bit, (diag1|bit)<<1, (diag2|bit)>>1)
+	}
+	return count
+}
+
 func main() {}
@@ -1,20 +1,24 @@
 package main
 
-import "unsafe"
+import (
+	"unsafe"
+
+	"github.com/clojurewasm/zwasm/bench/tinygo/scratch"
+)
 
 // Simulates real workload: allocate struct array, filter active records, sum values.
 // Param: record count (array size).
 // Returns: sum of active record values.
 //
 // Record layout: [id int32 (4), value int32 (4), active int32 (4)] = 12 bytes.
-// Max param: ~170000 (fits in 2MB scratch starting at offset 1024).
+// The backing region grows on demand via the scratch package, so there's no
+// fixed cap on n.
 
-const rwScratch = 1024
 const recordSize = 12
 
 //export real_work
 func real_work(n int32) int32 {
-	base := unsafe.Pointer(uintptr(rwScratch))
+	base := scratch.Reserve(uintptr(n) * recordSize)
 
 	// Build records
 	for i := int32(0); i < n; i++ {
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"unsafe"
+
+	"github.com/clojurewasm/zwasm/bench/tinygo/scratch"
+)
+
+// Suffix array construction via SA-IS (Nong, Zhang & Chen's induced-sorting
+// algorithm), run over a deterministically generated byte string of length
+// n. Param: string length. Returns: int32 checksum of the resulting SA.
+//
+// Every working array (the S/L type bitmap, bucket bounds, SA, and LMS
+// naming arrays) lives in the shared scratch region instead of the Go heap,
+// matching the style of sieve and list_build. Recursion on the reduced
+// string, when LMS names collide, uses ordinary call-stack frames.
+
+const saisAlphabet = 4
+
+//export sais
+func sais(n int32) int32 {
+	s := unsafe.Slice((*int32)(scratch.Reserve(uintptr(n)*4)), n)
+	for i := int32(0); i < n; i++ {
+		h := uint32(i)*2654435761 + 12345
+		s[i] = int32(h % saisAlphabet)
+	}
+
+	sa := saisSuffixArray(s, saisAlphabet)
+
+	var checksum int32
+	for i, v := range sa {
+		checksum += (v + int32(i)) * 31
+	}
+	return checksum
+}
+
+// saisSuffixArray returns the suffix array of s, whose characters are values
+// in [0, k). It appends a unique sentinel smaller than every character
+// before handing off to saisCore, which is what makes induced sorting
+// correct: without a sentinel there's no position guaranteed to be the
+// globally smallest suffix to induce everything else from.
+func saisSuffixArray(s []int32, k int32) []int32 {
+	n := int32(len(s))
+	aug := unsafe.Slice((*int32)(scratch.Reserve(uintptr(n+1)*4)), n+1)
+	for i := int32(0); i < n; i++ {
+		aug[i] = s[i] + 1
+	}
+	aug[n] = 0
+
+	augSA := unsafe.Slice((*int32)(scratch.Reserve(uintptr(n+1)*4)), n+1)
+	saisCore(aug, augSA, k+1)
+
+	// augSA[0] is always the sentinel suffix itself; drop it.
+	return augSA[1:]
+}
+
+// isLMSAt reports whether position i is a left-most S-type position, given
+// the S/L classification bitmap.
+func isLMSAt(isS []bool, i int32) bool {
+	return i > 0 && isS[i] && !isS[i-1]
+}
+
+// classify fills isS with the S/L type of every position of s: true for
+// S-type, false for L-type. The last position is always S-type.
+func classify(s []int32, isS []bool) {
+	n := int32(len(s))
+	isS[n-1] = true
+	for i := n - 2; i >= 0; i-- {
+		switch {
+		case s[i] < s[i+1]:
+			isS[i] = true
+		case s[i] > s[i+1]:
+			isS[i] = false
+		default:
+			isS[i] = isS[i+1]
+		}
+	}
+}
+
+// bucketBounds computes, for every character in [0,k), the index at which
+// its bucket starts (heads) and ends (tails) within sa.
+func bucketBounds(s []int32, k int32, heads, tails []int32) {
+	for i := int32(0); i < k; i++ {
+		heads[i] = 0
+	}
+	n := int32(len(s))
+	for i := int32(0); i < n; i++ {
+		heads[s[i]]++
+	}
+	sum := int32(0)
+	for i := int32(0); i < k; i++ {
+		c := heads[i]
+		heads[i] = sum
+		sum += c
+		tails[i] = sum
+	}
+}
+
+// induceFromLMSOrder seeds sa from lms (a set of LMS positions, in any
+// order) at the tails of their buckets, then runs the two induced-sort
+// passes that settle every other suffix: left-to-right induces L-type
+// positions to the front of their bucket, right-to-left induces S-type
+// positions to the back of theirs. Seeded in any order, this sorts the LMS
+// substrings correctly relative to each other, which is all the first call
+// (LMS positions in text order) needs for naming; sa as a whole is the true
+// suffix array only when lms is already in sorted suffix order, as in the
+// second call.
+func induceFromLMSOrder(s []int32, sa []int32, isS []bool, lms []int32, heads, tails, cursor []int32) {
+	n := int32(len(s))
+	for i := int32(0); i < n; i++ {
+		sa[i] = -1
+	}
+	copy(cursor, tails)
+	for i := int32(len(lms)) - 1; i >= 0; i-- {
+		pos := lms[i]
+		cursor[s[pos]]--
+		sa[cursor[s[pos]]] = pos
+	}
+
+	copy(cursor, heads)
+	for i := int32(0); i < n; i++ {
+		j := sa[i] - 1
+		if j >= 0 && !isS[j] {
+			sa[cursor[s[j]]] = j
+			cursor[s[j]]++
+		}
+	}
+
+	copy(cursor, tails)
+	for i := n - 1; i >= 0; i-- {
+		j := sa[i] - 1
+		if j >= 0 && isS[j] {
+			cursor[s[j]]--
+			sa[cursor[s[j]]] = j
+		}
+	}
+}
+
+// sameLMSSubstring reports whether the LMS substrings starting at a and b
+// are identical, where an LMS substring runs from one LMS position up to
+// and including the next (or the end of s).
+func sameLMSSubstring(s []int32, isS []bool, a, b int32) bool {
+	n := int32(len(s))
+	for i := int32(0); ; i++ {
+		if a+i >= n || b+i >= n {
+			return a+i >= n && b+i >= n
+		}
+		aLMS := isLMSAt(isS, a+i)
+		bLMS := isLMSAt(isS, b+i)
+		if i > 0 && aLMS && bLMS {
+			return true
+		}
+		if aLMS != bLMS || s[a+i] != s[b+i] {
+			return false
+		}
+	}
+}
+
+// saisCore fills sa[0:len(s)] with the suffix array of s, whose characters
+// are values in [0, k) with s[len(s)-1] == 0 as the unique, globally
+// smallest character. Recursion only ever shrinks both n and k, so it
+// terminates; the reduced string built below always ends in its own unique
+// smallest name for the same reason, so it satisfies this same precondition
+// without needing its own sentinel pass.
+func saisCore(s []int32, sa []int32, k int32) {
+	n := int32(len(s))
+	if n == 1 {
+		sa[0] = 0
+		return
+	}
+
+	isS := unsafe.Slice((*bool)(scratch.Reserve(uintptr(n))), n)
+	classify(s, isS)
+
+	heads := unsafe.Slice((*int32)(scratch.Reserve(uintptr(k)*4)), k)
+	tails := unsafe.Slice((*int32)(scratch.Reserve(uintptr(k)*4)), k)
+	cursor := unsafe.Slice((*int32)(scratch.Reserve(uintptr(k)*4)), k)
+	bucketBounds(s, k, heads, tails)
+
+	lmsPos := unsafe.Slice((*int32)(scratch.Reserve(uintptr(n)*4)), n)
+	lmsCount := int32(0)
+	for i := int32(0); i < n; i++ {
+		if isLMSAt(isS, i) {
+			lmsPos[lmsCount] = i
+			lmsCount++
+		}
+	}
+	lmsPos = lmsPos[:lmsCount]
+
+	// Pass 1: seed with LMS positions in text order. This leaves the LMS
+	// substrings in sorted order, but not yet the full suffixes.
+	induceFromLMSOrder(s, sa, isS, lmsPos, heads, tails, cursor)
+
+	// Name each distinct LMS substring, in the true sorted order induced
+	// sort just settled them into.
+	names := unsafe.Slice((*int32)(scratch.Reserve(uintptr(n)*4)), n)
+	for i := int32(0); i < n; i++ {
+		names[i] = -1
+	}
+	name := int32(0)
+	prev := int32(-1)
+	for i := int32(0); i < n; i++ {
+		pos := sa[i]
+		if !isLMSAt(isS, pos) {
+			continue
+		}
+		if prev >= 0 && !sameLMSSubstring(s, isS, prev, pos) {
+			name++
+		}
+		names[pos] = name
+		prev = pos
+	}
+	numNames := name + 1
+
+	// Build the reduced string over LMS positions, in text order (not
+	// sorted order), then either recurse or read the SA off directly if
+	// names are already unique.
+	reduced := unsafe.Slice((*int32)(scratch.Reserve(uintptr(lmsCount)*4)), lmsCount)
+	for i, pos := range lmsPos {
+		reduced[i] = names[pos]
+	}
+	reducedSA := unsafe.Slice((*int32)(scratch.Reserve(uintptr(lmsCount)*4)), lmsCount)
+	if numNames == lmsCount {
+		for i, v := range reduced {
+			reducedSA[v] = int32(i)
+		}
+	} else {
+		saisCore(reduced, reducedSA, numNames)
+	}
+
+	// Map the reduced SA back to LMS positions in the original string and
+	// induce-sort everything else from those, now-correctly-ordered, seeds.
+	sortedLMS := unsafe.Slice((*int32)(scratch.Reserve(uintptr(lmsCount)*4)), lmsCount)
+	for i, idx := range reducedSA {
+		sortedLMS[i] = lmsPos[idx]
+	}
+	induceFromLMSOrder(s, sa, isS, sortedLMS, heads, tails, cursor)
+}
+
+func main() {}
@@ -0,0 +1,60 @@
+// Package scratch provides a tiny bump allocator over WebAssembly linear
+// memory for benchmarks that need a scratch region without hard-coding an
+// offset or touching the Go/TinyGo heap. Every caller shares the same arena,
+// so two benchmarks linked into the same module never silently overlap.
+package scratch
+
+import "unsafe"
+
+// startOffset leaves room for the stack and TinyGo globals that live at the
+// bottom of linear memory, matching the offset every benchmark used before
+// this package existed.
+const startOffset = 1024
+
+const wasmPageSize = 65536
+
+//go:extern llvm.wasm.memory.size.i32
+func memorySize(mem int32) int32
+
+//go:extern llvm.wasm.memory.grow.i32
+func memoryGrow(mem int32, delta int32) int32
+
+var offset uintptr = startOffset
+
+// Reserve returns a pointer to n fresh, arena-private bytes, growing linear
+// memory first if the request would run past what's currently available.
+func Reserve(n uintptr) unsafe.Pointer {
+	EnsureBytes(n)
+	ptr := unsafe.Pointer(offset)
+	offset += n
+	return ptr
+}
+
+// EnsureBytes grows linear memory, if needed, so that n more bytes are
+// available at the current offset without reserving them.
+func EnsureBytes(n uintptr) {
+	want := offset + n
+	have := uintptr(memorySize(0)) * wasmPageSize
+	if want <= have {
+		return
+	}
+	pages := int32((want - have + wasmPageSize - 1) / wasmPageSize)
+	if memoryGrow(0, pages) < 0 {
+		panic("scratch: memory.grow failed")
+	}
+}
+
+// Reset rewinds the arena back to the start and zeroes the bytes that were
+// in use, so the next round of Reserve calls sees a clean region. It is also
+// exported as the wasm function "reset" so hosts can call it between
+// benchmark iterations.
+//
+//export reset
+func Reset() {
+	used := offset - startOffset
+	base := unsafe.Pointer(uintptr(startOffset))
+	for i := uintptr(0); i < used; i++ {
+		*(*byte)(unsafe.Add(base, i)) = 0
+	}
+	offset = startOffset
+}
@@ -1,23 +1,27 @@
 package main
 
-import "unsafe"
+import (
+	"unsafe"
 
-// Use a fixed region of wasm linear memory as scratch space.
-// Offset 1024 onwards (first 1024 bytes reserved for stack/globals).
-const scratchOffset = 1024
+	"github.com/clojurewasm/zwasm/bench/tinygo/scratch"
+)
 
 //export sieve
 func sieve(n int32) int32 {
-	// Use raw memory pointer for the flags array
-	base := unsafe.Pointer(uintptr(scratchOffset))
+	// Flags array, one byte per candidate, from the shared scratch region.
+	base := scratch.Reserve(uintptr(n))
 
 	// Initialize: set all bytes to 1 (prime candidate)
 	for i := int32(2); i < n; i++ {
 		*(*byte)(unsafe.Add(base, uintptr(i))) = 1
 	}
-	// Clear 0 and 1
-	*(*byte)(unsafe.Add(base, 0)) = 0
-	*(*byte)(unsafe.Add(base, 1)) = 0
+	// Clear 0 and 1, if the reserved region is big enough to hold them.
+	if n > 0 {
+		*(*byte)(unsafe.Add(base, 0)) = 0
+	}
+	if n > 1 {
+		*(*byte)(unsafe.Add(base, 1)) = 0
+	}
 
 	// Sieve
 	for i := int32(2); i*i < n; i++ {